@@ -0,0 +1,55 @@
+// Package ctxutil provides typed helpers for values threaded through a
+// context.Context. It replaces ad-hoc ctx.Value("someString") calls, which
+// risk collisions with values set by unrelated packages and give up Go's
+// type system, with an unexported key type and a With*/*From accessor pair
+// per value.
+//
+// Usage:
+//
+//	ctx = ctxutil.WithHeaders(ctx, http.Header{"X-Trace-Id": {"abc"}})
+//	...
+//	if h, ok := ctxutil.HeadersFrom(ctx); ok {
+//	    for k, v := range h {
+//	        req.Header[k] = append(req.Header[k], v...)
+//	    }
+//	}
+package ctxutil
+
+import (
+  "context"
+  "net/http"
+)
+
+// ctxKey is unexported so values set with the helpers below can never
+// collide with keys defined by other packages, even ones that also happen
+// to use an int or string key.
+type ctxKey int
+
+const (
+  headersKey ctxKey = iota
+  didKey
+)
+
+// WithHeaders returns a copy of ctx carrying h. Any request issued while
+// processing ctx can merge h onto its own headers via HeadersFrom.
+func WithHeaders(ctx context.Context, h http.Header) context.Context {
+  return context.WithValue(ctx, headersKey, h)
+}
+
+// HeadersFrom returns the headers attached with WithHeaders, if any.
+func HeadersFrom(ctx context.Context) (http.Header, bool) {
+  h, ok := ctx.Value(headersKey).(http.Header)
+  return h, ok
+}
+
+// WithDID returns a copy of ctx carrying the DID of the account a request is
+// being made on behalf of, e.g. for logging or error messages.
+func WithDID(ctx context.Context, did string) context.Context {
+  return context.WithValue(ctx, didKey, did)
+}
+
+// DIDFrom returns the DID attached with WithDID, if any.
+func DIDFrom(ctx context.Context) (string, bool) {
+  did, ok := ctx.Value(didKey).(string)
+  return did, ok
+}
@@ -0,0 +1,184 @@
+// Package bsky is a minimal client for the Bluesky / AT Protocol XRPC API.
+// It can be imported as a library rather than only run as a demo: create a
+// Client, call CreateSession, and use the typed methods to talk to a PDS.
+package bsky
+
+import (
+  "bytes"
+  "context"
+  "encoding/json"
+  "fmt"
+  "io"
+  "net/http"
+  "net/url"
+
+  "github.com/katesclau/go-context/internal/ctxutil"
+)
+
+// DefaultBaseURL is used when NewClient is given an empty base URL.
+const DefaultBaseURL = "https://bsky.social"
+
+// Client talks to a PDS (Personal Data Server) over XRPC. The zero value is
+// not usable; construct one with NewClient. BaseURL can point at any
+// self-hosted PDS, not just bsky.social.
+type Client struct {
+  HTTPClient *http.Client
+  BaseURL    string
+  Session    *Session
+
+  // signingKey is set by NewClientWithSigningKey to mint service auth JWTs
+  // locally instead of calling com.atproto.server.getServiceAuth.
+  signingKey *SigningKey
+}
+
+// NewClient returns a Client for the given PDS base URL. An empty baseURL
+// falls back to DefaultBaseURL. The returned client's HTTPClient is wrapped
+// in an AuthTransport so expired access tokens are refreshed and the
+// request retried automatically.
+func NewClient(baseURL string) *Client {
+  if baseURL == "" {
+    baseURL = DefaultBaseURL
+  }
+  c := &Client{BaseURL: baseURL}
+  c.HTTPClient = &http.Client{Transport: &AuthTransport{Client: c}}
+  return c
+}
+
+// EnableRateLimiting wraps the client's transport in a RateLimiter that
+// honors the RateLimit-* and Retry-After headers returned by the PDS. When
+// block is true, requests sleep until a throttled endpoint's budget resets;
+// otherwise they fail fast with a *RateLimitError.
+func (c *Client) EnableRateLimiting(block bool) {
+  if c.HTTPClient == nil {
+    c.HTTPClient = &http.Client{Transport: &AuthTransport{Client: c}}
+  }
+  c.HTTPClient.Transport = &RateLimiter{Base: c.HTTPClient.Transport, Block: block}
+}
+
+func (c *Client) httpClient() *http.Client {
+  if c.HTTPClient != nil {
+    return c.HTTPClient
+  }
+  return http.DefaultClient
+}
+
+// CreateSession authenticates with a handle and password via
+// com.atproto.server.createSession and stores the resulting Session on the
+// client.
+func (c *Client) CreateSession(ctx context.Context, handle, password string) (*Session, error) {
+  var res createSessionResponse
+  body := createSessionRequest{Identifier: handle, Password: password}
+  if err := c.post(ctx, "com.atproto.server.createSession", body, &res); err != nil {
+    return nil, err
+  }
+
+  c.Session = res.toSession()
+  return c.Session, nil
+}
+
+// GetProfile calls app.bsky.actor.getProfile for the given actor (handle or DID).
+func (c *Client) GetProfile(ctx context.Context, actor string) (*Profile, error) {
+  var out Profile
+  query := url.Values{"actor": {actor}}
+  if err := c.get(ctx, "app.bsky.actor.getProfile", query, &out); err != nil {
+    return nil, err
+  }
+  return &out, nil
+}
+
+// GetActorFeeds calls app.bsky.feed.getActorFeeds for the given actor.
+func (c *Client) GetActorFeeds(ctx context.Context, actor string) (*GetActorFeedsResponse, error) {
+  var out GetActorFeedsResponse
+  query := url.Values{"actor": {actor}}
+  if err := c.get(ctx, "app.bsky.feed.getActorFeeds", query, &out); err != nil {
+    return nil, err
+  }
+  return &out, nil
+}
+
+// GetAuthorFeed calls app.bsky.feed.getAuthorFeed for the given actor.
+func (c *Client) GetAuthorFeed(ctx context.Context, actor string) (*GetAuthorFeedResponse, error) {
+  var out GetAuthorFeedResponse
+  query := url.Values{"actor": {actor}}
+  if err := c.get(ctx, "app.bsky.feed.getAuthorFeed", query, &out); err != nil {
+    return nil, err
+  }
+  return &out, nil
+}
+
+// get issues an authenticated GET against the given XRPC method and decodes
+// the JSON response into out.
+func (c *Client) get(ctx context.Context, method string, query url.Values, out interface{}) error {
+  u := c.endpoint(method, query)
+
+  req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+  if err != nil {
+    return fmt.Errorf("bsky: creating request for %s: %w", method, err)
+  }
+
+  return c.do(req, out)
+}
+
+// post issues a POST with a JSON body against the given XRPC method and
+// decodes the JSON response into out. Like get, it goes through the client's
+// transport, which attaches the session's access token if there is one.
+func (c *Client) post(ctx context.Context, method string, body interface{}, out interface{}) error {
+  jsonBody, err := json.Marshal(body)
+  if err != nil {
+    return fmt.Errorf("bsky: marshalling request for %s: %w", method, err)
+  }
+
+  req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint(method, nil), bytes.NewReader(jsonBody))
+  if err != nil {
+    return fmt.Errorf("bsky: creating request for %s: %w", method, err)
+  }
+  req.Header.Set("Content-Type", "application/json")
+
+  return c.do(req, out)
+}
+
+// do sends req and decodes its JSON response into out. Any headers attached
+// to the request's context via ctxutil.WithHeaders (e.g. by a caller
+// embedding this client in a server handler) are merged on before the
+// request goes out, so callers never need to reach for a bare
+// ctx.Value("headers") string key.
+func (c *Client) do(req *http.Request, out interface{}) error {
+  if headers, ok := ctxutil.HeadersFrom(req.Context()); ok {
+    for key, values := range headers {
+      for _, value := range values {
+        req.Header.Add(key, value)
+      }
+    }
+  }
+
+  resp, err := c.httpClient().Do(req)
+  if err != nil {
+    return fmt.Errorf("bsky: request to %s failed: %w", req.URL, err)
+  }
+  defer resp.Body.Close()
+
+  body, err := io.ReadAll(resp.Body)
+  if err != nil {
+    return fmt.Errorf("bsky: reading response from %s: %w", req.URL, err)
+  }
+
+  if resp.StatusCode != http.StatusOK {
+    return fmt.Errorf("bsky: %s returned %s: %s", req.URL, resp.Status, body)
+  }
+
+  if out == nil {
+    return nil
+  }
+  if err := json.Unmarshal(body, out); err != nil {
+    return fmt.Errorf("bsky: unmarshalling response from %s: %w", req.URL, err)
+  }
+  return nil
+}
+
+func (c *Client) endpoint(method string, query url.Values) string {
+  u := fmt.Sprintf("%s/xrpc/%s", c.BaseURL, method)
+  if len(query) > 0 {
+    u += "?" + query.Encode()
+  }
+  return u
+}
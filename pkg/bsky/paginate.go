@@ -0,0 +1,95 @@
+package bsky
+
+import (
+  "context"
+  "net/url"
+  "strconv"
+)
+
+// Page is one page of results delivered over a channel returned by
+// Paginate. If Err is non-nil the page carries no items and is the last
+// value sent before the channel closes.
+type Page[T any] struct {
+  Items []T
+  Err   error
+}
+
+// PageFunc fetches a single page of XRPC results for the given cursor (the
+// first call is made with cursor == ""). nextCursor is the server's cursor
+// for the following page; an empty nextCursor means there is nothing left
+// to fetch.
+type PageFunc[T any] func(ctx context.Context, cursor string) (items []T, nextCursor string, err error)
+
+// Paginate drives fn across every page of a cursor-paginated XRPC endpoint
+// and streams each page onto the returned channel. It stops, and closes the
+// channel, once fn returns an empty cursor, fn returns an error (delivered
+// as the final Page), or ctx is done.
+func Paginate[T any](ctx context.Context, fn PageFunc[T]) <-chan Page[T] {
+  out := make(chan Page[T])
+
+  go func() {
+    defer close(out)
+
+    cursor := ""
+    for {
+      items, next, err := fn(ctx, cursor)
+      if err != nil {
+        select {
+        case out <- Page[T]{Err: err}:
+        case <-ctx.Done():
+        }
+        return
+      }
+
+      select {
+      case out <- Page[T]{Items: items}:
+      case <-ctx.Done():
+        return
+      }
+
+      if next == "" {
+        return
+      }
+      cursor = next
+    }
+  }()
+
+  return out
+}
+
+// GetAuthorFeedAll streams every page of app.bsky.feed.getAuthorFeed for
+// actor. limit sets the page size sent as the "limit" query parameter; a
+// non-positive limit leaves it unset so the server's default applies.
+func (c *Client) GetAuthorFeedAll(ctx context.Context, actor string, limit int) <-chan Page[FeedViewPost] {
+  return Paginate(ctx, func(ctx context.Context, cursor string) ([]FeedViewPost, string, error) {
+    var out GetAuthorFeedResponse
+    if err := c.get(ctx, "app.bsky.feed.getAuthorFeed", feedQuery(actor, cursor, limit), &out); err != nil {
+      return nil, "", err
+    }
+    return out.Feed, out.Cursor, nil
+  })
+}
+
+// GetActorFeedsAll streams every page of app.bsky.feed.getActorFeeds for
+// actor. limit sets the page size sent as the "limit" query parameter; a
+// non-positive limit leaves it unset so the server's default applies.
+func (c *Client) GetActorFeedsAll(ctx context.Context, actor string, limit int) <-chan Page[Generator] {
+  return Paginate(ctx, func(ctx context.Context, cursor string) ([]Generator, string, error) {
+    var out GetActorFeedsResponse
+    if err := c.get(ctx, "app.bsky.feed.getActorFeeds", feedQuery(actor, cursor, limit), &out); err != nil {
+      return nil, "", err
+    }
+    return out.Feeds, out.Cursor, nil
+  })
+}
+
+func feedQuery(actor, cursor string, limit int) url.Values {
+  query := url.Values{"actor": {actor}}
+  if cursor != "" {
+    query.Set("cursor", cursor)
+  }
+  if limit > 0 {
+    query.Set("limit", strconv.Itoa(limit))
+  }
+  return query
+}
@@ -0,0 +1,196 @@
+package bsky
+
+import (
+  "fmt"
+  "net/http"
+  "strconv"
+  "sync"
+  "time"
+)
+
+// RateLimitError is returned (instead of blocking) when a RateLimiter with
+// Block set to false would otherwise have to wait out a budget.
+type RateLimitError struct {
+  Endpoint  string
+  Remaining int
+  Reset     time.Time
+}
+
+func (e *RateLimitError) Error() string {
+  return fmt.Sprintf("bsky: rate limited on %s until %s", e.Endpoint, e.Reset.Format(time.RFC3339))
+}
+
+// endpointBudget tracks the most recently observed RateLimit-Remaining and
+// RateLimit-Reset headers for a single XRPC method.
+type endpointBudget struct {
+  mu        sync.Mutex
+  remaining int
+  haveLimit bool
+  reset     time.Time
+}
+
+// maxRateLimitRetries bounds how many times a single call retries after a
+// 429 before giving up, and minRetryAfterWait is the backoff used when a
+// 429 response carries no (or an unparseable) Retry-After header. Without
+// a floor, a server returning bare 429s with no header would otherwise be
+// hammered in a zero-wait retry loop.
+const (
+  maxRateLimitRetries = 3
+  minRetryAfterWait   = time.Second
+)
+
+// RateLimiter is an http.RoundTripper that tracks the RateLimit-Remaining
+// and RateLimit-Reset headers XRPC endpoints return, per endpoint, and
+// throttles requests once a budget is exhausted. The RateLimit-Limit header
+// is not read. It also
+// honors a 429 response's Retry-After header. It composes with
+// AuthTransport (or any other http.RoundTripper) via Base, so callers opt
+// in by wrapping whatever transport they already have.
+type RateLimiter struct {
+  Base http.RoundTripper
+
+  // Block, when true, sleeps until the budget resets instead of returning
+  // a *RateLimitError.
+  Block bool
+
+  budgets sync.Map // endpoint string -> *endpointBudget
+}
+
+func (rl *RateLimiter) base() http.RoundTripper {
+  if rl.Base != nil {
+    return rl.Base
+  }
+  return http.DefaultTransport
+}
+
+// RoundTrip implements http.RoundTripper. current starts as req itself (no
+// clone needed for the first attempt) and is re-derived via cloneForRetry
+// before each retry so a request with a body isn't resent with an
+// already-drained reader.
+func (rl *RateLimiter) RoundTrip(req *http.Request) (*http.Response, error) {
+  endpoint := req.URL.Path
+  current := req
+
+  for attempt := 0; ; attempt++ {
+    if wait, limited := rl.waitFor(endpoint); limited {
+      if err := rl.throttle(current, endpoint, wait, 0, time.Time{}); err != nil {
+        return nil, err
+      }
+    }
+
+    resp, err := rl.base().RoundTrip(current)
+    if err != nil {
+      return resp, err
+    }
+    rl.recordBudget(endpoint, resp.Header)
+
+    if resp.StatusCode != http.StatusTooManyRequests {
+      return resp, nil
+    }
+    resp.Body.Close()
+
+    if attempt >= maxRateLimitRetries {
+      return nil, fmt.Errorf("bsky: giving up on %s after %d 429 responses", endpoint, attempt+1)
+    }
+
+    wait := retryAfter(resp.Header.Get("Retry-After"))
+    if wait <= 0 {
+      wait = minRetryAfterWait
+    }
+    if err := rl.throttle(current, endpoint, wait, 0, time.Now().Add(wait)); err != nil {
+      return nil, err
+    }
+
+    current, err = cloneForRetry(req)
+    if err != nil {
+      return nil, fmt.Errorf("bsky: rebuilding request body for retry: %w", err)
+    }
+  }
+}
+
+// waitFor reports how long to wait before endpoint has budget remaining,
+// based on the last response seen for it.
+func (rl *RateLimiter) waitFor(endpoint string) (time.Duration, bool) {
+  v, ok := rl.budgets.Load(endpoint)
+  if !ok {
+    return 0, false
+  }
+  b := v.(*endpointBudget)
+
+  b.mu.Lock()
+  defer b.mu.Unlock()
+  if !b.haveLimit || b.remaining > 0 {
+    return 0, false
+  }
+  wait := time.Until(b.reset)
+  if wait <= 0 {
+    return 0, false
+  }
+  return wait, true
+}
+
+// throttle blocks for wait, or returns a *RateLimitError if rl.Block is
+// false, or bails out early if req's context is done.
+func (rl *RateLimiter) throttle(req *http.Request, endpoint string, wait time.Duration, remaining int, reset time.Time) error {
+  if !rl.Block {
+    if reset.IsZero() {
+      reset = time.Now().Add(wait)
+    }
+    return &RateLimitError{Endpoint: endpoint, Remaining: remaining, Reset: reset}
+  }
+
+  select {
+  case <-time.After(wait):
+    return nil
+  case <-req.Context().Done():
+    return req.Context().Err()
+  }
+}
+
+func (rl *RateLimiter) recordBudget(endpoint string, header http.Header) {
+  remaining, rok := parseIntHeader(header.Get("RateLimit-Remaining"))
+  resetSec, sok := parseIntHeader(header.Get("RateLimit-Reset"))
+  if !rok && !sok {
+    return
+  }
+
+  v, _ := rl.budgets.LoadOrStore(endpoint, &endpointBudget{})
+  b := v.(*endpointBudget)
+
+  b.mu.Lock()
+  defer b.mu.Unlock()
+  if rok {
+    b.remaining = remaining
+    b.haveLimit = true
+  }
+  if sok {
+    b.reset = time.Unix(int64(resetSec), 0)
+  }
+}
+
+func parseIntHeader(v string) (int, bool) {
+  if v == "" {
+    return 0, false
+  }
+  n, err := strconv.Atoi(v)
+  if err != nil {
+    return 0, false
+  }
+  return n, true
+}
+
+// retryAfter parses a Retry-After header, which per RFC 9110 is either a
+// number of seconds or an HTTP-date. It returns 0 if the header is missing
+// or unparseable.
+func retryAfter(v string) time.Duration {
+  if v == "" {
+    return 0
+  }
+  if secs, err := strconv.Atoi(v); err == nil {
+    return time.Duration(secs) * time.Second
+  }
+  if t, err := http.ParseTime(v); err == nil {
+    return time.Until(t)
+  }
+  return 0
+}
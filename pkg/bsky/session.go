@@ -0,0 +1,52 @@
+package bsky
+
+// Session holds the tokens and identity returned by createSession. It is
+// stored on the Client so subsequent calls can authenticate themselves.
+type Session struct {
+  AccessJwt  string
+  RefreshJwt string
+  Did        string
+  Handle     string
+  DidDoc     DidDoc
+}
+
+// createSessionRequest is the body sent to com.atproto.server.createSession.
+type createSessionRequest struct {
+  Identifier string `json:"identifier"`
+  Password   string `json:"password"`
+}
+
+// createSessionResponse mirrors the wire shape of createSession before it is
+// collapsed into a Session.
+type createSessionResponse struct {
+  Did             string `json:"did"`
+  DidDoc          DidDoc `json:"didDoc"`
+  Handle          string `json:"handle"`
+  Email           string `json:"email"`
+  EmailConfirmed  bool   `json:"emailConfirmed"`
+  EmailAuthFactor bool   `json:"emailAuthFactor"`
+  AccessJwt       string `json:"accessJwt"`
+  RefreshJwt      string `json:"refreshJwt"`
+  Active          bool   `json:"active"`
+}
+
+// refreshSessionResponse mirrors the wire shape of
+// com.atproto.server.refreshSession.
+type refreshSessionResponse struct {
+  Did        string `json:"did"`
+  DidDoc     DidDoc `json:"didDoc"`
+  Handle     string `json:"handle"`
+  AccessJwt  string `json:"accessJwt"`
+  RefreshJwt string `json:"refreshJwt"`
+  Active     bool   `json:"active"`
+}
+
+func (r createSessionResponse) toSession() *Session {
+  return &Session{
+    AccessJwt:  r.AccessJwt,
+    RefreshJwt: r.RefreshJwt,
+    Did:        r.Did,
+    Handle:     r.Handle,
+    DidDoc:     r.DidDoc,
+  }
+}
@@ -0,0 +1,140 @@
+package bsky
+
+import (
+  "context"
+  "encoding/json"
+  "fmt"
+  "io"
+  "net/http"
+  "sync"
+
+  "github.com/katesclau/go-context/internal/ctxutil"
+)
+
+// AuthTransport is an http.RoundTripper that attaches the Client's current
+// access token to every request and, on a 401 response, refreshes the
+// session via com.atproto.server.refreshSession and retries the request
+// once. Refreshes are serialized with mu so concurrent requests sharing a
+// Client don't each kick off their own refresh.
+type AuthTransport struct {
+  Base   http.RoundTripper
+  Client *Client
+
+  mu sync.Mutex
+}
+
+func (t *AuthTransport) base() http.RoundTripper {
+  if t.Base != nil {
+    return t.Base
+  }
+  return http.DefaultTransport
+}
+
+// RoundTrip implements http.RoundTripper. It never modifies req itself (per
+// the http.RoundTripper contract); it clones before attaching the bearer
+// token and before any retry.
+func (t *AuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+  tokenUsed := t.currentAccessJwt()
+  authedReq := req.Clone(req.Context())
+  setBearer(authedReq, tokenUsed)
+
+  resp, err := t.base().RoundTrip(authedReq)
+  if err != nil || resp.StatusCode != http.StatusUnauthorized {
+    return resp, err
+  }
+  resp.Body.Close()
+
+  if err := t.refreshIfStale(req.Context(), tokenUsed); err != nil {
+    if did, ok := ctxutil.DIDFrom(req.Context()); ok {
+      return nil, fmt.Errorf("bsky: refreshing session for %s after 401: %w", did, err)
+    }
+    return nil, fmt.Errorf("bsky: refreshing session after 401: %w", err)
+  }
+
+  retryReq, err := cloneForRetry(req)
+  if err != nil {
+    return nil, fmt.Errorf("bsky: rebuilding request body for retry: %w", err)
+  }
+  setBearer(retryReq, t.currentAccessJwt())
+  return t.base().RoundTrip(retryReq)
+}
+
+func (t *AuthTransport) currentAccessJwt() string {
+  t.mu.Lock()
+  defer t.mu.Unlock()
+  if t.Client.Session == nil {
+    return ""
+  }
+  return t.Client.Session.AccessJwt
+}
+
+// refreshIfStale calls refreshSession unless another goroutine already
+// rotated the token past staleToken while this one was waiting on mu.
+func (t *AuthTransport) refreshIfStale(ctx context.Context, staleToken string) error {
+  t.mu.Lock()
+  defer t.mu.Unlock()
+
+  session := t.Client.Session
+  if session == nil || session.RefreshJwt == "" {
+    return fmt.Errorf("no refresh token available")
+  }
+  if session.AccessJwt != staleToken {
+    return nil
+  }
+
+  req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.Client.endpoint("com.atproto.server.refreshSession", nil), nil)
+  if err != nil {
+    return err
+  }
+  setBearer(req, session.RefreshJwt)
+
+  resp, err := t.base().RoundTrip(req)
+  if err != nil {
+    return err
+  }
+  defer resp.Body.Close()
+
+  body, err := io.ReadAll(resp.Body)
+  if err != nil {
+    return err
+  }
+  if resp.StatusCode != http.StatusOK {
+    return fmt.Errorf("refreshSession returned %s: %s", resp.Status, body)
+  }
+
+  var refreshed refreshSessionResponse
+  if err := json.Unmarshal(body, &refreshed); err != nil {
+    return err
+  }
+
+  session.AccessJwt = refreshed.AccessJwt
+  session.RefreshJwt = refreshed.RefreshJwt
+  session.Did = refreshed.Did
+  session.Handle = refreshed.Handle
+  return nil
+}
+
+func setBearer(req *http.Request, token string) {
+  if token != "" {
+    req.Header.Set("Authorization", "Bearer "+token)
+  }
+}
+
+// cloneForRetry clones req for a retry attempt, re-deriving a fresh Body
+// from GetBody when one is set so retries of requests with a body (e.g. a
+// POST with a JSON payload) don't resend an already-drained reader.
+// Requests built from a []byte/bytes.Reader/strings.Reader body, as
+// Client.post's are, get GetBody populated automatically by net/http.
+func cloneForRetry(req *http.Request) (*http.Request, error) {
+  clone := req.Clone(req.Context())
+  if req.GetBody == nil {
+    return clone, nil
+  }
+
+  body, err := req.GetBody()
+  if err != nil {
+    return nil, err
+  }
+  clone.Body = body
+  return clone, nil
+}
@@ -0,0 +1,83 @@
+package bsky
+
+import "encoding/json"
+
+// VerificationMethod is an entry in a DID document's verificationMethod list.
+type VerificationMethod struct {
+  ID                 string `json:"id"`
+  Type               string `json:"type"`
+  Controller         string `json:"controller"`
+  PublicKeyMultibase string `json:"publicKeyMultibase"`
+}
+
+// Service is an entry in a DID document's service list.
+type Service struct {
+  ID              string `json:"id"`
+  Type            string `json:"type"`
+  ServiceEndpoint string `json:"serviceEndpoint"`
+}
+
+// DidDoc is the DID document returned alongside a session by createSession
+// and refreshSession.
+type DidDoc struct {
+  Context            []string             `json:"@context"`
+  ID                 string               `json:"id"`
+  AlsoKnownAs        []string             `json:"alsoKnownAs"`
+  VerificationMethod []VerificationMethod `json:"verificationMethod"`
+  Service            []Service            `json:"service"`
+}
+
+// Profile is the response shape of app.bsky.actor.getProfile.
+type Profile struct {
+  Did            string `json:"did"`
+  Handle         string `json:"handle"`
+  DisplayName    string `json:"displayName"`
+  Description    string `json:"description"`
+  AvatarURL      string `json:"avatar"`
+  FollowersCount int    `json:"followersCount"`
+  FollowsCount   int    `json:"followsCount"`
+  PostsCount     int    `json:"postsCount"`
+}
+
+// Generator is a feed generator as returned by app.bsky.feed.getActorFeeds.
+type Generator struct {
+  URI         string `json:"uri"`
+  Cid         string `json:"cid"`
+  Did         string `json:"did"`
+  DisplayName string `json:"displayName"`
+  Description string `json:"description"`
+}
+
+// GetActorFeedsResponse is the response shape of app.bsky.feed.getActorFeeds.
+type GetActorFeedsResponse struct {
+  Cursor string      `json:"cursor"`
+  Feeds  []Generator `json:"feeds"`
+}
+
+// ProfileBasic is the trimmed-down author embedded in a feed item.
+type ProfileBasic struct {
+  Did         string `json:"did"`
+  Handle      string `json:"handle"`
+  DisplayName string `json:"displayName"`
+}
+
+// PostView is a single post as embedded in a feed response. Record is left
+// as raw JSON since post records are a union of many lexicon types.
+type PostView struct {
+  URI       string          `json:"uri"`
+  Cid       string          `json:"cid"`
+  Author    ProfileBasic    `json:"author"`
+  Record    json.RawMessage `json:"record"`
+  IndexedAt string          `json:"indexedAt"`
+}
+
+// FeedViewPost wraps a PostView as returned in a feed's "feed" array.
+type FeedViewPost struct {
+  Post PostView `json:"post"`
+}
+
+// GetAuthorFeedResponse is the response shape of app.bsky.feed.getAuthorFeed.
+type GetAuthorFeedResponse struct {
+  Cursor string         `json:"cursor"`
+  Feed   []FeedViewPost `json:"feed"`
+}